@@ -0,0 +1,45 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command ingress-gce-webhook runs the validating admission webhook that
+// rejects Services and Ingresses carrying malformed cloud.google.com
+// annotations before they are persisted.
+package main
+
+import (
+	"flag"
+	"net/http"
+
+	"k8s.io/klog"
+
+	"k8s.io/ingress-gce/pkg/webhook"
+)
+
+var (
+	addr     = flag.String("addr", ":8443", "address the webhook listens on")
+	certFile = flag.String("tls-cert-file", "", "path to the webhook's TLS certificate")
+	keyFile  = flag.String("tls-private-key-file", "", "path to the webhook's TLS private key")
+)
+
+func main() {
+	flag.Parse()
+
+	mux := http.NewServeMux()
+	webhook.NewServer().RegisterHandlers(mux)
+
+	klog.Infof("ingress-gce-webhook listening on %s", *addr)
+	klog.Fatal(http.ListenAndServeTLS(*addr, *certFile, *keyFile, mux))
+}
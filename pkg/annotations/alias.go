@@ -0,0 +1,100 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package annotations
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+// AliasEntry names a deprecated annotation key that FromService still
+// honors as a fallback for a newer, canonical key.
+type AliasEntry struct {
+	// Canonical is the annotation key controllers should prefer.
+	Canonical string
+	// Deprecated is the older key honored when Canonical is absent.
+	Deprecated string
+	// RemovedIn is the release in which Deprecated will stop being
+	// honored, surfaced to operators via DeprecatedAnnotation events.
+	RemovedIn string
+}
+
+// AnnotationAliases lists every deprecated annotation key still honored as
+// a fallback, and the canonical key that has superseded it. Entries here
+// keep the override precedence (canonical wins when both are present)
+// explicit and give operators a migration deadline, the same way Kong and
+// ingress-nginx phased out their own legacy annotation keys.
+var AnnotationAliases = []AliasEntry{
+	{Canonical: ServiceApplicationProtocolKey, Deprecated: GoogleServiceApplicationProtocolKey, RemovedIn: "v1.30"},
+}
+
+// ResolveAnnotation looks up key's value, falling back to any deprecated
+// alias of key listed in AnnotationAliases. It reports whether a
+// deprecated alias supplied the value and, if so, which annotation key
+// that was. A deprecated alias used this way is recorded on svc for later
+// reporting via DeprecatedAnnotationsUsed and EmitDeprecationEvents.
+func (svc *Service) ResolveAnnotation(key string) (value string, usedDeprecated bool, alias string) {
+	if v, ok := svc.v[key]; ok {
+		return v, false, ""
+	}
+	for _, entry := range AnnotationAliases {
+		if entry.Canonical != key {
+			continue
+		}
+		if v, ok := svc.v[entry.Deprecated]; ok {
+			if !svc.recordDeprecated(entry) {
+				deprecatedAnnotationTotal.WithLabelValues(entry.Deprecated).Inc()
+			}
+			return v, true, entry.Deprecated
+		}
+	}
+	return "", false, ""
+}
+
+// recordDeprecated records entry as used on svc, unless it was already
+// recorded by an earlier ResolveAnnotation call on the same Service
+// wrapper, and reports whether it was already recorded. Getters such as
+// ApplicationProtocols may call ResolveAnnotation for the same key more
+// than once, and resolving the same alias twice must not double-count it.
+func (svc *Service) recordDeprecated(entry AliasEntry) (alreadyRecorded bool) {
+	for _, e := range svc.deprecated {
+		if e == entry {
+			return true
+		}
+	}
+	svc.deprecated = append(svc.deprecated, entry)
+	return false
+}
+
+// DeprecatedAnnotationsUsed returns the alias entries that ResolveAnnotation
+// fell back to while serving this Service's annotations, in resolution
+// order.
+func (svc *Service) DeprecatedAnnotationsUsed() []AliasEntry {
+	return svc.deprecated
+}
+
+// EmitDeprecationEvents emits a DeprecatedAnnotation warning Event on obj
+// (typically the Service itself) for every deprecated alias this Service
+// resolved an annotation through.
+func (svc *Service) EmitDeprecationEvents(obj runtime.Object, recorder record.EventRecorder) {
+	for _, entry := range svc.deprecated {
+		recorder.Eventf(obj, v1.EventTypeWarning, "DeprecatedAnnotation",
+			"annotation %q is deprecated and will be removed in %s; use %q instead",
+			entry.Deprecated, entry.RemovedIn, entry.Canonical)
+	}
+}
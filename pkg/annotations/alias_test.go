@@ -0,0 +1,44 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package annotations
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestResolveAnnotationDeduplicatesRepeatedCalls(t *testing.T) {
+	svc := FromService(&v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				GoogleServiceApplicationProtocolKey: `{"80":"HTTP"}`,
+			},
+		},
+	})
+
+	for i := 0; i < 3; i++ {
+		if _, usedDeprecated, _ := svc.ResolveAnnotation(ServiceApplicationProtocolKey); !usedDeprecated {
+			t.Fatalf("call %d: ResolveAnnotation(...) usedDeprecated = false; want true", i)
+		}
+	}
+
+	if got := svc.DeprecatedAnnotationsUsed(); len(got) != 1 {
+		t.Errorf("DeprecatedAnnotationsUsed() = %v; want exactly one entry after repeated resolution", got)
+	}
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package annotations
+
+import (
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+const (
+	// IngressClassKey is the annotation used to select which Ingress
+	// controller should reconcile an Ingress.
+	IngressClassKey = "kubernetes.io/ingress.class"
+	// AllowHTTPKey, if set to "false", disables the HTTP (port 80)
+	// forwarding rule, leaving only HTTPS in place.
+	AllowHTTPKey = "kubernetes.io/ingress.allow-http"
+	// PreSharedCertKey names one or more pre-existing Google-managed or
+	// self-managed SSL certificates to attach to the Ingress, as a
+	// comma-separated list.
+	PreSharedCertKey = "ingress.gcp.kubernetes.io/pre-shared-cert"
+)
+
+// Ingress represents Ingress annotations.
+type Ingress struct {
+	v map[string]string
+}
+
+// FromIngress extracts the annotations map from an Ingress.
+func FromIngress(obj *networkingv1.Ingress) *Ingress {
+	return &Ingress{obj.Annotations}
+}
+
+// IngressClass returns the value of the ingress class annotation.
+func (ing *Ingress) IngressClass() string {
+	return ing.v[IngressClassKey]
+}
+
+// AllowHTTP returns whether the HTTP forwarding rule should be created.
+// It defaults to true when the annotation is absent or unparsable.
+func (ing *Ingress) AllowHTTP() bool {
+	v, ok := ing.v[AllowHTTPKey]
+	if !ok {
+		return true
+	}
+	return v != "false"
+}
+
+// PreSharedCert returns the comma-separated list of pre-shared certificate
+// names, or nil if the annotation is absent.
+func (ing *Ingress) PreSharedCert() []string {
+	v, ok := ing.v[PreSharedCertKey]
+	if !ok || v == "" {
+		return nil
+	}
+	return strings.Split(v, ",")
+}
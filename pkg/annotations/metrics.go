@@ -0,0 +1,35 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package annotations
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// deprecatedAnnotationTotal counts deprecated annotation aliases (see
+// AnnotationAliases) consulted in place of their canonical key, labeled by
+// the deprecated key that was used. Each alias is counted at most once per
+// Service wrapper, however often ResolveAnnotation falls back to it.
+var deprecatedAnnotationTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "ingress_gce_deprecated_annotation_total",
+		Help: "Number of times a deprecated annotation alias was used instead of its canonical key.",
+	},
+	[]string{"key"},
+)
+
+func init() {
+	prometheus.MustRegister(deprecatedAnnotationTotal)
+}
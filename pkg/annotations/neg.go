@@ -0,0 +1,126 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package annotations
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/ingress-gce/pkg/annotations/validation"
+)
+
+const (
+	// NegEndpointTypeGCEVMIPPort is the default NEG endpoint type: one
+	// network endpoint per (VM instance, port) pair. This is the type used
+	// for regular standalone and ingress-attached NEGs.
+	NegEndpointTypeGCEVMIPPort = "GCE_VM_IP_PORT"
+	// NegEndpointTypeNonGCPPrivateIPPort opts a NEG into hybrid mode, where
+	// endpoints may live outside of GCE (e.g. on-prem), identified by a
+	// private IP and port rather than a GCE instance.
+	NegEndpointTypeNonGCPPrivateIPPort = "NON_GCP_PRIVATE_IP_PORT"
+)
+
+// NegAnnotation represents the value of the NEG annotation.
+type NegAnnotation struct {
+	Ingress      bool                    `json:"ingress,omitempty"`
+	ExposedPorts map[int32]NegAttributes `json:"exposed_ports,omitempty"`
+}
+
+// NegAttributes houses the optional per-port attributes of the NEG
+// annotation.
+type NegAttributes struct {
+	// Name pins the generated NEG to an explicit name instead of letting
+	// the NEG controller derive one, which avoids the name changing (and
+	// any attached load balancing state having to catch up) whenever the
+	// NEG is re-created. Must be a valid NEG name (DNS-1035 label).
+	Name string `json:"name,omitempty"`
+	// EndpointType selects the kind of network endpoint the NEG holds.
+	// Defaults to NegEndpointTypeGCEVMIPPort.
+	// NegEndpointTypeNonGCPPrivateIPPort opts into a hybrid NEG for
+	// endpoints that live outside of GCE.
+	EndpointType string `json:"endpointType,omitempty"`
+	// NetworkEndpointGroupAnnotations holds annotations for the generated
+	// NEG object. It is parsed but not validated, and no code here copies
+	// it onto a NEG.
+	NetworkEndpointGroupAnnotations map[string]string `json:"networkEndpointGroupAnnotations,omitempty"`
+	// ReadOnly marks the NEG as managed outside of this controller. It is
+	// parsed only; the helpers in pkg/neg/syncers do not consult it, and
+	// no code here skips creating, updating or deleting a read-only NEG.
+	ReadOnly bool `json:"readOnly,omitempty"`
+}
+
+// NEGEnabled returns true if the service uses NEG on any port.
+func (n *NegAnnotation) NEGEnabled() bool {
+	return n.NEGEnabledForIngress() || n.NEGExposed()
+}
+
+// NEGEnabledForIngress returns true if the service uses NEG for ingress.
+func (n *NegAnnotation) NEGEnabledForIngress() bool {
+	return n.Ingress
+}
+
+// NEGExposed returns true if the service exposes NEG on any port.
+func (n *NegAnnotation) NEGExposed() bool {
+	return len(n.ExposedPorts) > 0
+}
+
+// validate checks that every port's NegAttributes is internally
+// well-formed and that no two ports pin the same explicit NEG name.
+func (n *NegAnnotation) validate() error {
+	namedPorts := make(map[string]int32)
+	for port, attrs := range n.ExposedPorts {
+		switch attrs.EndpointType {
+		case "", NegEndpointTypeGCEVMIPPort, NegEndpointTypeNonGCPPrivateIPPort:
+		default:
+			return fmt.Errorf("%w: port %d: unknown endpointType %q", ErrNEGAttributesInvalid, port, attrs.EndpointType)
+		}
+
+		if attrs.Name == "" {
+			continue
+		}
+		if errs := utilvalidation.IsDNS1035Label(attrs.Name); len(errs) > 0 {
+			return fmt.Errorf("%w: port %d: invalid name %q: %s", ErrNEGAttributesInvalid, port, attrs.Name, strings.Join(errs, "; "))
+		}
+		if other, ok := namedPorts[attrs.Name]; ok {
+			return fmt.Errorf("%w: port %d: name %q collides with the NEG name already pinned for port %d", ErrNEGAttributesInvalid, port, attrs.Name, other)
+		}
+		namedPorts[attrs.Name] = port
+	}
+	return nil
+}
+
+// NEGAnnotation returns the parsed NEG annotation, whether it was found,
+// and an error if it could not be parsed or fails validation.
+func (svc *Service) NEGAnnotation() (bool, *NegAnnotation, error) {
+	v, ok := svc.v[NEGAnnotationKey]
+	if !ok {
+		return false, nil, nil
+	}
+
+	var res NegAnnotation
+	if err := json.Unmarshal([]byte(v), &res); err != nil {
+		return true, nil, validation.Wrap(validation.NEGAnnotationPath, ErrNEGAnnotationInvalid)
+	}
+
+	if err := res.validate(); err != nil {
+		return true, nil, validation.Wrap(validation.NEGAnnotationPath, err)
+	}
+
+	return true, &res, nil
+}
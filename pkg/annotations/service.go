@@ -0,0 +1,230 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package annotations
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/ingress-gce/pkg/annotations/validation"
+	"k8s.io/ingress-gce/pkg/flags"
+)
+
+const (
+	// NEGAnnotationKey is the annotation key used by NEG (Network Endpoint
+	// Group) feature to specify parameters for the NEGs associated with a
+	// Service.
+	NEGAnnotationKey = "cloud.google.com/neg"
+
+	// ServiceApplicationProtocolKey is the annotation used to specify the
+	// protocol used by each backend port.
+	ServiceApplicationProtocolKey = "cloud.google.com/app-protocols"
+	// GoogleServiceApplicationProtocolKey is the legacy annotation used to
+	// specify the protocol used by each backend port. It is superseded by
+	// ServiceApplicationProtocolKey, which takes precedence when both are
+	// present.
+	GoogleServiceApplicationProtocolKey = "service.alpha.kubernetes.io/app-protocols"
+
+	// BackendConfigKey is the annotation used to associate a BackendConfig
+	// with a Service port.
+	BackendConfigKey = "cloud.google.com/backend-config"
+)
+
+var (
+	// ErrNEGAnnotationInvalid is returned when the NEG annotation cannot be
+	// parsed as valid JSON. Invalid per-port fields are reported with
+	// ErrNEGAttributesInvalid instead.
+	ErrNEGAnnotationInvalid = errors.New("error parsing the NEG annotation")
+	// ErrNEGAttributesInvalid is returned when a port's NegAttributes
+	// specifies an unknown endpointType, an invalid name, or a name that
+	// collides with another port's.
+	ErrNEGAttributesInvalid = errors.New("invalid NEG attributes")
+	// ErrBackendConfigAnnotationMissing is returned when the Service has no
+	// BackendConfig annotation at all.
+	ErrBackendConfigAnnotationMissing = errors.New("no BackendConfig for the given backend")
+	// ErrBackendConfigInvalidJSON is returned when the BackendConfig
+	// annotation value is not valid JSON.
+	ErrBackendConfigInvalidJSON = errors.New("BackendConfig annotation is invalid json")
+	// ErrBackendConfigNoneFound is returned when the BackendConfig
+	// annotation parses but specifies neither a default nor any ports.
+	ErrBackendConfigNoneFound = errors.New("no BackendConfig's found in annotation")
+	// ErrBackendConfigTLSInvalid is returned when the BackendConfig's
+	// "tls" stanza names a source but is missing the fields that source
+	// requires.
+	ErrBackendConfigTLSInvalid = errors.New("BackendConfig tls stanza is invalid")
+	// ErrBackendConfigTLSSourceUnknown is returned when the BackendConfig's
+	// "tls" stanza is present but names no recognized external secret
+	// source.
+	ErrBackendConfigTLSSourceUnknown = errors.New("BackendConfig tls stanza names no recognized source")
+)
+
+// AppProtocol describes the application protocol accepted by a Service
+// backend port.
+type AppProtocol string
+
+const (
+	// ProtocolHTTP is the default application protocol.
+	ProtocolHTTP AppProtocol = "HTTP"
+	// ProtocolHTTPS designates a backend that terminates TLS.
+	ProtocolHTTPS AppProtocol = "HTTPS"
+	// ProtocolHTTP2 designates a backend served over HTTP/2 in cleartext.
+	// It requires flags.F.Features.Http2 to be enabled.
+	ProtocolHTTP2 AppProtocol = "HTTP2"
+	// ProtocolGRPC designates a backend served over gRPC in cleartext
+	// (i.e. HTTP/2 without TLS). It requires flags.F.Features.GRPC to be
+	// enabled.
+	ProtocolGRPC AppProtocol = "GRPC"
+	// ProtocolGRPCS designates a backend served over gRPC with TLS. It
+	// requires flags.F.Features.GRPC to be enabled.
+	ProtocolGRPCS AppProtocol = "GRPCS"
+)
+
+// Service represents Service annotations.
+type Service struct {
+	v map[string]string
+	// deprecated accumulates, in resolution order, the alias entries that
+	// ResolveAnnotation fell back to while serving this Service's
+	// annotations. See DeprecatedAnnotationsUsed.
+	deprecated []AliasEntry
+}
+
+// FromService extracts the annotations map from a Service.
+func FromService(obj *v1.Service) *Service {
+	return &Service{v: obj.Annotations}
+}
+
+// ApplicationProtocols returns the per-port application protocol map
+// specified via ServiceApplicationProtocolKey, falling back to the legacy
+// GoogleServiceApplicationProtocolKey when the former is absent.
+func (svc *Service) ApplicationProtocols() (map[string]AppProtocol, error) {
+	val, _, _ := svc.ResolveAnnotation(ServiceApplicationProtocolKey)
+	if val == "" {
+		return map[string]AppProtocol{}, nil
+	}
+
+	var portToProtos map[string]AppProtocol
+	if err := json.Unmarshal([]byte(val), &portToProtos); err != nil {
+		return nil, validation.Wrap(validation.AppProtocolsAnnotationPath, err)
+	}
+
+	// Verify protocol is an accepted value
+	for _, protocol := range portToProtos {
+		switch protocol {
+		case ProtocolHTTP, ProtocolHTTPS:
+		case ProtocolHTTP2:
+			if !flags.F.Features.Http2 {
+				return nil, validation.Wrap(validation.AppProtocolsAnnotationPath, fmt.Errorf("Http2 not enabled as port application protocol"))
+			}
+		case ProtocolGRPC, ProtocolGRPCS:
+			if !flags.F.Features.GRPC {
+				return nil, validation.Wrap(validation.AppProtocolsAnnotationPath, fmt.Errorf("GRPC not enabled as port application protocol"))
+			}
+		default:
+			return nil, validation.Wrap(validation.AppProtocolsAnnotationPath, fmt.Errorf("unknown port application protocol: %v", protocol))
+		}
+	}
+
+	return portToProtos, nil
+}
+
+// BackendConfigs is the JSON structure of the backend-config annotation.
+type BackendConfigs struct {
+	Default string            `json:"default,omitempty"`
+	Ports   map[string]string `json:"ports,omitempty"`
+	// TLS configures where the client TLS certificate for HTTPS/HTTP2
+	// backends should be fetched from, as an alternative to pointing a
+	// BackendConfig at an in-cluster Secret.
+	TLS *BackendTLS `json:"tls,omitempty"`
+}
+
+// BackendTLS names the external source a backend's client TLS certificate
+// should be fetched from.
+type BackendTLS struct {
+	// ClientCertSource fetches the cert from an SDS server, identified by
+	// cluster name and resource name as in the xDS ClientCertificate
+	// pattern used by service mesh sidecars.
+	ClientCertSource *ClientCertSource `json:"clientCertSource,omitempty"`
+}
+
+// ClientCertSource identifies a certificate resource served by an SDS
+// (Secret Discovery Service) cluster, such as Secret Manager's SDS-
+// compatible endpoint or a mesh's own SDS server.
+type ClientCertSource struct {
+	// SDSCluster is the name of the SDS cluster to query.
+	SDSCluster string `json:"sdsCluster"`
+	// ResourceName is the name of the certificate resource within
+	// SDSCluster.
+	ResourceName string `json:"resourceName"`
+}
+
+// BackendTLSSource is the resolved, validated descriptor of where a
+// backend's client TLS certificate should come from.
+type BackendTLSSource struct {
+	SDSCluster   string
+	ResourceName string
+}
+
+// GetBackendConfigs returns the BackendConfigs for the given service.
+func (svc *Service) GetBackendConfigs() (*BackendConfigs, error) {
+	val, ok := svc.v[BackendConfigKey]
+	if !ok {
+		return nil, ErrBackendConfigAnnotationMissing
+	}
+
+	configs := BackendConfigs{}
+	if err := json.Unmarshal([]byte(val), &configs); err != nil {
+		return nil, validation.Wrap(validation.BackendConfigAnnotationPath, ErrBackendConfigInvalidJSON)
+	}
+
+	if configs.Default == "" && len(configs.Ports) == 0 {
+		return nil, validation.Wrap(validation.BackendConfigAnnotationPath, ErrBackendConfigNoneFound)
+	}
+
+	return &configs, nil
+}
+
+// GetBackendTLSSource returns the external source the service's
+// BackendConfig names for client TLS certificate material, or nil if the
+// service has no BackendConfig, or its BackendConfig has no "tls" stanza.
+func (svc *Service) GetBackendTLSSource() (*BackendTLSSource, error) {
+	configs, err := svc.GetBackendConfigs()
+	if errors.Is(err, ErrBackendConfigAnnotationMissing) || errors.Is(err, ErrBackendConfigNoneFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if configs.TLS == nil {
+		return nil, nil
+	}
+
+	cert := configs.TLS.ClientCertSource
+	if cert == nil {
+		return nil, ErrBackendConfigTLSSourceUnknown
+	}
+	if cert.SDSCluster == "" || cert.ResourceName == "" {
+		return nil, ErrBackendConfigTLSInvalid
+	}
+
+	return &BackendTLSSource{
+		SDSCluster:   cert.SDSCluster,
+		ResourceName: cert.ResourceName,
+	}, nil
+}
@@ -17,7 +17,7 @@ limitations under the License.
 package annotations
 
 import (
-	"fmt"
+	"errors"
 	"reflect"
 	"testing"
 
@@ -136,10 +136,55 @@ func TestNEGAnnotation(t *testing.T) {
 			ingress:    true,
 			exposed:    true,
 		},
+		{
+			desc: "Explicit NEG name",
+			svc: &v1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						NEGAnnotationKey: `{"exposed_ports":{"80":{"name":"my-neg"}}}`,
+					},
+				},
+			},
+			expectFound: true,
+			expectNegAnnotation: &NegAnnotation{
+				ExposedPorts: map[int32]NegAttributes{int32(80): {Name: "my-neg"}},
+			},
+			negEnabled: true,
+			ingress:    false,
+			exposed:    true,
+		},
+		{
+			desc: "Invalid endpointType",
+			svc: &v1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						NEGAnnotationKey: `{"exposed_ports":{"80":{"endpointType":"BOGUS"}}}`,
+					},
+				},
+			},
+			expectFound: true,
+			expectError: ErrNEGAttributesInvalid,
+		},
+		{
+			desc: "Name collision between ports",
+			svc: &v1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						NEGAnnotationKey: `{"exposed_ports":{"80":{"name":"my-neg"}, "443":{"name":"my-neg"}}}`,
+					},
+				},
+			},
+			expectFound: true,
+			expectError: ErrNEGAttributesInvalid,
+		},
 	} {
 		found, negAnnotation, err := FromService(tc.svc).NEGAnnotation()
-		if fmt.Sprintf("%q", err) != fmt.Sprintf("%q", tc.expectError) {
-			t.Errorf("Test case %q: Expect error to be %q, but got: %q", tc.desc, tc.expectError, err)
+		if tc.expectError == nil {
+			if err != nil {
+				t.Errorf("Test case %q: Expect error to be nil, but got: %q", tc.desc, err)
+			}
+		} else if !errors.Is(err, tc.expectError) {
+			t.Errorf("Test case %q: Expect error to wrap %q, but got: %q", tc.desc, tc.expectError, err)
 		}
 
 		if found != tc.expectFound {
@@ -174,6 +219,8 @@ func TestService(t *testing.T) {
 		appProtocolsErr bool
 		appProtocols    map[string]AppProtocol
 		http2           bool
+		grpc            bool
+		usedDeprecated  bool
 	}{
 		{
 			svc:          &v1.Service{},
@@ -187,7 +234,8 @@ func TestService(t *testing.T) {
 					},
 				},
 			},
-			appProtocols: map[string]AppProtocol{"80": "HTTP", "443": "HTTPS"},
+			appProtocols:   map[string]AppProtocol{"80": "HTTP", "443": "HTTPS"},
+			usedDeprecated: true, // only the deprecated alias is set
 		},
 		{
 			svc: &v1.Service{
@@ -198,7 +246,8 @@ func TestService(t *testing.T) {
 					},
 				},
 			},
-			appProtocols: map[string]AppProtocol{"81": "HTTP", "444": "HTTPS"},
+			appProtocols:   map[string]AppProtocol{"81": "HTTP", "444": "HTTPS"},
+			usedDeprecated: false, // canonical key wins over the deprecated alias
 		},
 		{
 			svc: &v1.Service{
@@ -252,8 +301,42 @@ func TestService(t *testing.T) {
 			},
 			appProtocolsErr: true,
 		},
+		{
+			svc: &v1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						ServiceApplicationProtocolKey: `{"443": "GRPC"}`,
+					},
+				},
+			},
+			appProtocols:    map[string]AppProtocol{"443": "GRPC"},
+			appProtocolsErr: true, // Without the grpc flag enabled, expect error
+		},
+		{
+			svc: &v1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						ServiceApplicationProtocolKey: `{"443": "GRPCS"}`,
+					},
+				},
+			},
+			appProtocols: map[string]AppProtocol{"443": "GRPCS"},
+			grpc:         true,
+		},
+		{
+			svc: &v1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						ServiceApplicationProtocolKey: `{"80": "HTTP", "443": "GRPC"}`,
+					},
+				},
+			},
+			appProtocols: map[string]AppProtocol{"80": "HTTP", "443": "GRPC"},
+			grpc:         true,
+		},
 	} {
 		flags.F.Features.Http2 = tc.http2
+		flags.F.Features.GRPC = tc.grpc
 		svc := FromService(tc.svc)
 		ap, err := svc.ApplicationProtocols()
 		if tc.appProtocolsErr {
@@ -265,6 +348,9 @@ func TestService(t *testing.T) {
 		if err != nil || !reflect.DeepEqual(ap, tc.appProtocols) {
 			t.Errorf("for service %+v; svc.ApplicationProtocols() = %v, %v; want %v, nil", tc.svc, ap, err, tc.appProtocols)
 		}
+		if usedDeprecated := len(svc.DeprecatedAnnotationsUsed()) > 0; usedDeprecated != tc.usedDeprecated {
+			t.Errorf("for service %+v; svc.DeprecatedAnnotationsUsed() non-empty = %v; want %v", tc.svc, usedDeprecated, tc.usedDeprecated)
+		}
 	}
 }
 
@@ -349,13 +435,101 @@ func TestBackendConfigs(t *testing.T) {
 			},
 			expectedErr: ErrBackendConfigNoneFound,
 		},
+		{
+			desc: "backendConfig with tls clientCertSource",
+			svc: &v1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						BackendConfigKey: `{"default": "config-default", "tls": {"clientCertSource": {"sdsCluster": "secretmanager", "resourceName": "projects/p/secrets/s/versions/latest"}}}`,
+					},
+				},
+			},
+			expectedConfigs: &BackendConfigs{
+				Default: "config-default",
+				TLS: &BackendTLS{
+					ClientCertSource: &ClientCertSource{
+						SDSCluster:   "secretmanager",
+						ResourceName: "projects/p/secrets/s/versions/latest",
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range testcases {
 		svc := FromService(tc.svc)
 		configs, err := svc.GetBackendConfigs()
-		if !reflect.DeepEqual(configs, tc.expectedConfigs) || tc.expectedErr != err {
+		errMismatch := tc.expectedErr == nil && err != nil || tc.expectedErr != nil && !errors.Is(err, tc.expectedErr)
+		if !reflect.DeepEqual(configs, tc.expectedConfigs) || errMismatch {
 			t.Errorf("%s: for annotations %+v; svc.GetBackendConfigs() = %v, %v; want %v, %v", tc.desc, svc.v, configs, err, tc.expectedConfigs, tc.expectedErr)
 		}
 	}
 }
+
+func TestGetBackendTLSSource(t *testing.T) {
+	for _, tc := range []struct {
+		desc        string
+		svc         *v1.Service
+		expectedSrc *BackendTLSSource
+		expectedErr error
+	}{
+		{
+			desc:        "no backend-config annotation",
+			svc:         &v1.Service{},
+			expectedSrc: nil,
+		},
+		{
+			desc: "no tls stanza",
+			svc: &v1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						BackendConfigKey: `{"default": "config-default"}`,
+					},
+				},
+			},
+			expectedSrc: nil,
+		},
+		{
+			desc: "valid clientCertSource",
+			svc: &v1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						BackendConfigKey: `{"default": "config-default", "tls": {"clientCertSource": {"sdsCluster": "secretmanager", "resourceName": "projects/p/secrets/s/versions/latest"}}}`,
+					},
+				},
+			},
+			expectedSrc: &BackendTLSSource{
+				SDSCluster:   "secretmanager",
+				ResourceName: "projects/p/secrets/s/versions/latest",
+			},
+		},
+		{
+			desc: "tls stanza with no recognized source",
+			svc: &v1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						BackendConfigKey: `{"default": "config-default", "tls": {}}`,
+					},
+				},
+			},
+			expectedErr: ErrBackendConfigTLSSourceUnknown,
+		},
+		{
+			desc: "clientCertSource missing resourceName",
+			svc: &v1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						BackendConfigKey: `{"default": "config-default", "tls": {"clientCertSource": {"sdsCluster": "secretmanager"}}}`,
+					},
+				},
+			},
+			expectedErr: ErrBackendConfigTLSInvalid,
+		},
+	} {
+		src, err := FromService(tc.svc).GetBackendTLSSource()
+		errMismatch := tc.expectedErr == nil && err != nil || tc.expectedErr != nil && !errors.Is(err, tc.expectedErr)
+		if !reflect.DeepEqual(src, tc.expectedSrc) || errMismatch {
+			t.Errorf("%s: GetBackendTLSSource() = %v, %v; want %v, %v", tc.desc, src, err, tc.expectedSrc, tc.expectedErr)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var (
+	// AllowHTTPAnnotationPath is the field path of the allow-http
+	// annotation on an Ingress.
+	AllowHTTPAnnotationPath = field.NewPath("metadata", "annotations").Key("kubernetes.io/ingress.allow-http")
+	// PreSharedCertAnnotationPath is the field path of the pre-shared-cert
+	// annotation on an Ingress.
+	PreSharedCertAnnotationPath = field.NewPath("metadata", "annotations").Key("ingress.gcp.kubernetes.io/pre-shared-cert")
+)
+
+// ValidateAllowHTTPAnnotation checks that raw, the unparsed value of the
+// allow-http annotation, is a valid boolean.
+func ValidateAllowHTTPAnnotation(raw string) field.ErrorList {
+	var allErrs field.ErrorList
+	if raw != "true" && raw != "false" {
+		allErrs = append(allErrs, field.Invalid(AllowHTTPAnnotationPath, raw, `must be "true" or "false"`))
+	}
+	return allErrs
+}
+
+// ValidatePreSharedCertAnnotation checks that raw, the unparsed value of
+// the pre-shared-cert annotation, is a non-empty comma-separated list of
+// non-empty certificate names.
+func ValidatePreSharedCertAnnotation(raw string) field.ErrorList {
+	var allErrs field.ErrorList
+	names := strings.Split(raw, ",")
+	for _, name := range names {
+		if strings.TrimSpace(name) == "" {
+			allErrs = append(allErrs, field.Invalid(PreSharedCertAnnotationPath, raw, "must not contain empty certificate names"))
+			break
+		}
+	}
+	return allErrs
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var (
+	// NEGAnnotationPath is the field path of the NEG annotation on a
+	// Service.
+	NEGAnnotationPath = field.NewPath("metadata", "annotations").Key("cloud.google.com/neg")
+	// AppProtocolsAnnotationPath is the field path of the application
+	// protocols annotation on a Service.
+	AppProtocolsAnnotationPath = field.NewPath("metadata", "annotations").Key("cloud.google.com/app-protocols")
+	// GoogleAppProtocolsAnnotationPath is the field path of the legacy
+	// application protocols annotation on a Service.
+	GoogleAppProtocolsAnnotationPath = field.NewPath("metadata", "annotations").Key("service.alpha.kubernetes.io/app-protocols")
+	// BackendConfigAnnotationPath is the field path of the backend-config
+	// annotation on a Service.
+	BackendConfigAnnotationPath = field.NewPath("metadata", "annotations").Key("cloud.google.com/backend-config")
+
+	knownAppProtocols = map[string]bool{
+		"HTTP": true, "HTTPS": true, "HTTP2": true, "GRPC": true, "GRPCS": true,
+	}
+
+	// knownNegEndpointTypes are the valid values for a NEG annotation
+	// port's endpointType; "" defaults to GCE_VM_IP_PORT.
+	knownNegEndpointTypes = map[string]bool{
+		"": true, "GCE_VM_IP_PORT": true, "NON_GCP_PRIVATE_IP_PORT": true,
+	}
+)
+
+// ValidateNEGAnnotation checks that raw, the unparsed value of the NEG
+// annotation, is syntactically valid JSON of the shape the NEG controller
+// understands: exposed_ports keyed by decimal port number, with per-port
+// attributes naming a known endpointType and a DNS-1035-compliant name
+// that is not reused across ports.
+func ValidateNEGAnnotation(raw string) field.ErrorList {
+	var allErrs field.ErrorList
+	var parsed struct {
+		Ingress      bool `json:"ingress"`
+		ExposedPorts map[string]struct {
+			Name         string `json:"name"`
+			EndpointType string `json:"endpointType"`
+		} `json:"exposed_ports"`
+	}
+	if err := json.Unmarshal([]byte(raw), &parsed); err != nil {
+		return append(allErrs, field.Invalid(NEGAnnotationPath, raw, err.Error()))
+	}
+
+	seenNames := map[string]string{}
+	for port, attrs := range parsed.ExposedPorts {
+		portPath := NEGAnnotationPath.Child("exposed_ports").Key(port)
+		if err := validatePortNumber(port); err != nil {
+			allErrs = append(allErrs, field.Invalid(portPath, port, err.Error()))
+		}
+		if !knownNegEndpointTypes[attrs.EndpointType] {
+			allErrs = append(allErrs, field.NotSupported(portPath.Child("endpointType"), attrs.EndpointType, []string{"GCE_VM_IP_PORT", "NON_GCP_PRIVATE_IP_PORT"}))
+		}
+		if attrs.Name == "" {
+			continue
+		}
+		if errs := utilvalidation.IsDNS1035Label(attrs.Name); len(errs) > 0 {
+			allErrs = append(allErrs, field.Invalid(portPath.Child("name"), attrs.Name, strings.Join(errs, "; ")))
+			continue
+		}
+		if other, ok := seenNames[attrs.Name]; ok {
+			allErrs = append(allErrs, field.Invalid(portPath.Child("name"), attrs.Name, fmt.Sprintf("collides with the name already pinned for port %s", other)))
+			continue
+		}
+		seenNames[attrs.Name] = port
+	}
+	return allErrs
+}
+
+// ValidateAppProtocolsAnnotation checks that raw, the unparsed value of
+// either the app-protocols annotation or its legacy alias, is valid JSON
+// mapping port names to one of the known AppProtocol values.
+func ValidateAppProtocolsAnnotation(path *field.Path, raw string) field.ErrorList {
+	var allErrs field.ErrorList
+	var portToProto map[string]string
+	if err := json.Unmarshal([]byte(raw), &portToProto); err != nil {
+		return append(allErrs, field.Invalid(path, raw, err.Error()))
+	}
+	for port, proto := range portToProto {
+		if !knownAppProtocols[proto] {
+			allErrs = append(allErrs, field.NotSupported(path.Key(port), proto, []string{"HTTP", "HTTPS", "HTTP2", "GRPC", "GRPCS"}))
+		}
+	}
+	return allErrs
+}
+
+// ValidateBackendConfigAnnotation checks that raw, the unparsed value of
+// the backend-config annotation, is valid JSON naming at least a default
+// or one per-port BackendConfig.
+func ValidateBackendConfigAnnotation(raw string) field.ErrorList {
+	var allErrs field.ErrorList
+	var parsed struct {
+		Default string            `json:"default"`
+		Ports   map[string]string `json:"ports"`
+	}
+	if err := json.Unmarshal([]byte(raw), &parsed); err != nil {
+		return append(allErrs, field.Invalid(BackendConfigAnnotationPath, raw, err.Error()))
+	}
+	if parsed.Default == "" && len(parsed.Ports) == 0 {
+		allErrs = append(allErrs, field.Invalid(BackendConfigAnnotationPath, raw, "must set \"default\" or at least one port under \"ports\""))
+	}
+	return allErrs
+}
+
+func validatePortNumber(port string) error {
+	n, err := strconv.Atoi(port)
+	if err != nil {
+		return fmt.Errorf("must be a decimal port number")
+	}
+	if n <= 0 || n > 65535 {
+		return fmt.Errorf("must be between 1 and 65535")
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import "testing"
+
+func TestValidateNEGAnnotation(t *testing.T) {
+	for _, tc := range []struct {
+		desc    string
+		raw     string
+		wantErr bool
+	}{
+		{desc: "malformed JSON", raw: `foo`, wantErr: true},
+		{desc: "non-numeric port key", raw: `{"exposed_ports":{"http":{}}}`, wantErr: true},
+		{desc: "valid single port", raw: `{"exposed_ports":{"80":{}}}`, wantErr: false},
+		{desc: "valid multiple ports", raw: `{"exposed_ports":{"80":{}, "443":{}}}`, wantErr: false},
+		{desc: "explicit name", raw: `{"exposed_ports":{"80":{"name":"my-neg"}}}`, wantErr: false},
+		{desc: "invalid endpointType", raw: `{"exposed_ports":{"80":{"endpointType":"BOGUS"}}}`, wantErr: true},
+		{desc: "hybrid endpointType", raw: `{"exposed_ports":{"80":{"endpointType":"NON_GCP_PRIVATE_IP_PORT"}}}`, wantErr: false},
+		{desc: "colliding names across ports", raw: `{"exposed_ports":{"80":{"name":"my-neg"}, "443":{"name":"my-neg"}}}`, wantErr: true},
+	} {
+		errs := ValidateNEGAnnotation(tc.raw)
+		if (len(errs) > 0) != tc.wantErr {
+			t.Errorf("%s: ValidateNEGAnnotation(%q) = %v; wantErr %v", tc.desc, tc.raw, errs, tc.wantErr)
+		}
+	}
+}
+
+func TestValidateAppProtocolsAnnotation(t *testing.T) {
+	for _, tc := range []struct {
+		desc    string
+		raw     string
+		wantErr bool
+	}{
+		{desc: "unknown protocol", raw: `{"80": "SSH"}`, wantErr: true},
+		{desc: "known protocols", raw: `{"80": "HTTP", "443": "HTTPS"}`, wantErr: false},
+		{desc: "grpc protocols", raw: `{"443": "GRPCS"}`, wantErr: false},
+	} {
+		errs := ValidateAppProtocolsAnnotation(AppProtocolsAnnotationPath, tc.raw)
+		if (len(errs) > 0) != tc.wantErr {
+			t.Errorf("%s: ValidateAppProtocolsAnnotation(%q) = %v; wantErr %v", tc.desc, tc.raw, errs, tc.wantErr)
+		}
+	}
+}
+
+func TestValidateBackendConfigAnnotation(t *testing.T) {
+	for _, tc := range []struct {
+		desc    string
+		raw     string
+		wantErr bool
+	}{
+		{desc: "malformed JSON", raw: `invalid`, wantErr: true},
+		{desc: "key typo", raw: `{"portstypo":{"https": "config-https"}}`, wantErr: true},
+		{desc: "valid ports", raw: `{"ports":{"http": "config-http"}}`, wantErr: false},
+		{desc: "valid default", raw: `{"default": "config-default"}`, wantErr: false},
+	} {
+		errs := ValidateBackendConfigAnnotation(tc.raw)
+		if (len(errs) > 0) != tc.wantErr {
+			t.Errorf("%s: ValidateBackendConfigAnnotation(%q) = %v; wantErr %v", tc.desc, tc.raw, errs, tc.wantErr)
+		}
+	}
+}
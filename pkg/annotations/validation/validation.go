@@ -0,0 +1,57 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package validation provides typed, path-qualified validation of the
+// Service and Ingress annotations consumed by the ingress-gce controllers.
+// It is shared between the controllers themselves, which use it to turn
+// sentinel parse errors into errors that name the offending field, and the
+// ingress-gce-webhook admission webhook, which uses it to reject bad
+// annotations before they ever reach etcd.
+package validation
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Error pairs a sentinel annotation-parsing error with the field path of
+// the annotation value that triggered it, so callers can report precisely
+// which key (and, for map-valued annotations, which nested key) was
+// malformed.
+type Error struct {
+	Path *field.Path
+	Err  error
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %v", e.Path.String(), e.Err)
+}
+
+// Unwrap allows errors.Is/errors.As to see through to the sentinel error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Wrap annotates err, a sentinel annotation-parsing error, with the field
+// path of the annotation that produced it. It returns nil if err is nil.
+func Wrap(path *field.Path, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Path: path, Err: err}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backends
+
+import (
+	"context"
+
+	compute "google.golang.org/api/compute/v1"
+
+	"k8s.io/ingress-gce/pkg/annotations"
+	"k8s.io/ingress-gce/pkg/backends/tlsprovider"
+)
+
+// ServicePort is the resolved, ready-to-apply GCE configuration for one
+// port of a Service's backend service.
+type ServicePort struct {
+	// Protocol is the GCE backend service protocol to use for this port.
+	Protocol string
+	// HealthCheck is the health check this port's backend service should
+	// use, or nil if the caller should build its usual HTTP(S)/HTTP2
+	// health check instead.
+	HealthCheck *compute.HealthCheck
+	// ClientCert is the client TLS certificate this port's backend
+	// service should present when connecting to endpoints, or nil if
+	// the backend protocol is HTTP or the BackendConfig names none.
+	ClientCert *tlsprovider.Cert
+}
+
+// ComposeServicePort translates one port of svc into the GCE backend
+// service configuration the backend-service reconciler should apply: the
+// backend protocol and health check for the port's application protocol
+// (translating GRPC/GRPCS into an HTTP2 backend service paired with a
+// gRPC health check), plus whatever client TLS certificate svc's
+// BackendConfig names for HTTPS/HTTP2 backends.
+func ComposeServicePort(ctx context.Context, svc *annotations.Service, providers tlsprovider.Providers, hcName, grpcServiceName string, port int64, protocol annotations.AppProtocol) (*ServicePort, error) {
+	gceProtocol, hc, err := healthCheckForPort(hcName, grpcServiceName, port, protocol)
+	if err != nil {
+		return nil, err
+	}
+
+	var cert *tlsprovider.Cert
+	if gceProtocol == "HTTPS" || gceProtocol == "HTTP2" {
+		cert, err = clientTLSCert(ctx, svc, providers)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &ServicePort{
+		Protocol:    gceProtocol,
+		HealthCheck: hc,
+		ClientCert:  cert,
+	}, nil
+}
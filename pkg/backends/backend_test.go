@@ -0,0 +1,95 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backends
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"k8s.io/ingress-gce/pkg/annotations"
+	"k8s.io/ingress-gce/pkg/backends/tlsprovider"
+)
+
+func TestComposeServicePort(t *testing.T) {
+	wantCert := &tlsprovider.Cert{CertPEM: []byte("cert"), KeyPEM: []byte("key")}
+
+	for _, tc := range []struct {
+		desc         string
+		svc          *v1.Service
+		providers    tlsprovider.Providers
+		protocol     annotations.AppProtocol
+		wantProtocol string
+		wantHC       bool
+		wantCert     *tlsprovider.Cert
+		wantErr      bool
+	}{
+		{
+			desc:         "HTTPS port with no BackendConfig gets no health check override or cert",
+			svc:          &v1.Service{},
+			protocol:     annotations.ProtocolHTTPS,
+			wantProtocol: "HTTPS",
+		},
+		{
+			desc:         "GRPC port gets an HTTP2 backend and a gRPC health check",
+			svc:          &v1.Service{},
+			protocol:     annotations.ProtocolGRPC,
+			wantProtocol: "HTTP2",
+			wantHC:       true,
+		},
+		{
+			desc: "HTTP2 port with a configured client cert fetches it",
+			svc: &v1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						annotations.BackendConfigKey: `{"default": "config-default", "tls": {"clientCertSource": {"sdsCluster": "secretmanager", "resourceName": "projects/p/secrets/s/versions/latest"}}}`,
+					},
+				},
+			},
+			providers:    tlsprovider.Providers{"secretmanager": &fakeProvider{cert: wantCert}},
+			protocol:     annotations.ProtocolHTTP2,
+			wantProtocol: "HTTP2",
+			wantCert:     wantCert,
+		},
+		{
+			desc:     "unknown protocol errors",
+			svc:      &v1.Service{},
+			protocol: annotations.AppProtocol("SSH"),
+			wantErr:  true,
+		},
+	} {
+		sp, err := ComposeServicePort(context.Background(), annotations.FromService(tc.svc), tc.providers, "my-hc", "my.Service", 8080, tc.protocol)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("%s: ComposeServicePort(...) error = %v; wantErr %v", tc.desc, err, tc.wantErr)
+			continue
+		}
+		if tc.wantErr {
+			continue
+		}
+		if sp.Protocol != tc.wantProtocol {
+			t.Errorf("%s: sp.Protocol = %q; want %q", tc.desc, sp.Protocol, tc.wantProtocol)
+		}
+		if (sp.HealthCheck != nil) != tc.wantHC {
+			t.Errorf("%s: sp.HealthCheck = %v; want non-nil %v", tc.desc, sp.HealthCheck, tc.wantHC)
+		}
+		if sp.ClientCert != tc.wantCert {
+			t.Errorf("%s: sp.ClientCert = %v; want %v", tc.desc, sp.ClientCert, tc.wantCert)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backends
+
+import (
+	"fmt"
+
+	compute "google.golang.org/api/compute/v1"
+
+	"k8s.io/ingress-gce/pkg/annotations"
+	"k8s.io/ingress-gce/pkg/healthchecks"
+)
+
+// toGCEProtocol translates a port's AppProtocol into the GCE backend
+// service protocol that should be used to reach it, along with whether the
+// port is gRPC and therefore needs a gRPC health check rather than an
+// HTTP(S) one.
+//
+// GRPC and GRPCS are not GCE backend service protocols in their own right;
+// both are carried over a protocol HTTP2 backend service, with TLS (if
+// any) implied by the load balancer's frontend configuration, and
+// distinguished from plain HTTP2 only by the health check they pair with.
+func toGCEProtocol(protocol annotations.AppProtocol) (gceProtocol string, isGRPC bool, err error) {
+	switch protocol {
+	case annotations.ProtocolHTTP:
+		return "HTTP", false, nil
+	case annotations.ProtocolHTTPS:
+		return "HTTPS", false, nil
+	case annotations.ProtocolHTTP2:
+		return "HTTP2", false, nil
+	case annotations.ProtocolGRPC, annotations.ProtocolGRPCS:
+		return "HTTP2", true, nil
+	default:
+		return "", false, fmt.Errorf("unknown port application protocol: %v", protocol)
+	}
+}
+
+// healthCheckForPort returns the GCE backend service protocol to use for a
+// port exposing protocol, plus the health check that backend service
+// should get. For GRPC/GRPCS ports that's a gRPC health check (probing
+// grpcServiceName, or the server's overall status if empty); for every
+// other protocol it's nil, signaling that the caller should build its
+// usual HTTP(S)/HTTP2 health check instead.
+func healthCheckForPort(name, grpcServiceName string, port int64, protocol annotations.AppProtocol) (gceProtocol string, hc *compute.HealthCheck, err error) {
+	gceProtocol, isGRPC, err := toGCEProtocol(protocol)
+	if err != nil {
+		return "", nil, err
+	}
+	if !isGRPC {
+		return gceProtocol, nil, nil
+	}
+	return gceProtocol, healthchecks.NewGRPCHealthCheck(name, grpcServiceName, port), nil
+}
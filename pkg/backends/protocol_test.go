@@ -0,0 +1,82 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backends
+
+import (
+	"testing"
+
+	"k8s.io/ingress-gce/pkg/annotations"
+)
+
+func TestToGCEProtocol(t *testing.T) {
+	for _, tc := range []struct {
+		desc         string
+		protocol     annotations.AppProtocol
+		wantProtocol string
+		wantGRPC     bool
+		wantErr      bool
+	}{
+		{desc: "HTTP", protocol: annotations.ProtocolHTTP, wantProtocol: "HTTP"},
+		{desc: "HTTPS", protocol: annotations.ProtocolHTTPS, wantProtocol: "HTTPS"},
+		{desc: "HTTP2", protocol: annotations.ProtocolHTTP2, wantProtocol: "HTTP2"},
+		{desc: "GRPC", protocol: annotations.ProtocolGRPC, wantProtocol: "HTTP2", wantGRPC: true},
+		{desc: "GRPCS", protocol: annotations.ProtocolGRPCS, wantProtocol: "HTTP2", wantGRPC: true},
+		{desc: "unknown", protocol: annotations.AppProtocol("SSH"), wantErr: true},
+	} {
+		gotProtocol, gotGRPC, err := toGCEProtocol(tc.protocol)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("%s: toGCEProtocol(%v) error = %v; wantErr %v", tc.desc, tc.protocol, err, tc.wantErr)
+			continue
+		}
+		if tc.wantErr {
+			continue
+		}
+		if gotProtocol != tc.wantProtocol || gotGRPC != tc.wantGRPC {
+			t.Errorf("%s: toGCEProtocol(%v) = %q, %v; want %q, %v", tc.desc, tc.protocol, gotProtocol, gotGRPC, tc.wantProtocol, tc.wantGRPC)
+		}
+	}
+}
+
+func TestHealthCheckForPort(t *testing.T) {
+	for _, tc := range []struct {
+		desc      string
+		protocol  annotations.AppProtocol
+		wantGCEHC bool
+		wantErr   bool
+	}{
+		{desc: "HTTP gets no gRPC health check", protocol: annotations.ProtocolHTTP, wantGCEHC: false},
+		{desc: "HTTP2 gets no gRPC health check", protocol: annotations.ProtocolHTTP2, wantGCEHC: false},
+		{desc: "GRPC gets a gRPC health check", protocol: annotations.ProtocolGRPC, wantGCEHC: true},
+		{desc: "GRPCS gets a gRPC health check", protocol: annotations.ProtocolGRPCS, wantGCEHC: true},
+		{desc: "unknown protocol errors", protocol: annotations.AppProtocol("SSH"), wantErr: true},
+	} {
+		gceProtocol, hc, err := healthCheckForPort("my-hc", "my.Service", 8080, tc.protocol)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("%s: healthCheckForPort(...) error = %v; wantErr %v", tc.desc, err, tc.wantErr)
+			continue
+		}
+		if tc.wantErr {
+			continue
+		}
+		if gceProtocol != "HTTP2" && tc.wantGCEHC {
+			t.Errorf("%s: gceProtocol = %q; want HTTP2 for a gRPC backend", tc.desc, gceProtocol)
+		}
+		if (hc != nil) != tc.wantGCEHC {
+			t.Errorf("%s: healthCheckForPort(...) hc = %v; want non-nil %v", tc.desc, hc, tc.wantGCEHC)
+		}
+	}
+}
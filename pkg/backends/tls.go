@@ -0,0 +1,51 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backends
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/ingress-gce/pkg/annotations"
+	"k8s.io/ingress-gce/pkg/backends/tlsprovider"
+)
+
+// clientTLSCert resolves the client TLS certificate for an HTTPS/HTTP2
+// backend named by svc's BackendConfig, fetching it through providers if
+// the BackendConfig points at an external secret source. It returns nil,
+// nil if the BackendConfig has no "tls" stanza, so callers fall back to
+// whatever in-cluster Secret they already resolve.
+func clientTLSCert(ctx context.Context, svc *annotations.Service, providers tlsprovider.Providers) (*tlsprovider.Cert, error) {
+	src, err := svc.GetBackendTLSSource()
+	if err != nil {
+		return nil, err
+	}
+	if src == nil {
+		return nil, nil
+	}
+
+	provider, ok := providers.Get(src.SDSCluster)
+	if !ok {
+		return nil, fmt.Errorf("no TLS provider registered for sdsCluster %q", src.SDSCluster)
+	}
+
+	cert, err := provider.FetchClientCert(ctx, src.SDSCluster, src.ResourceName)
+	if err != nil {
+		return nil, fmt.Errorf("fetching client TLS cert for resource %q: %w", src.ResourceName, err)
+	}
+	return cert, nil
+}
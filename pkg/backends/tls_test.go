@@ -0,0 +1,113 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backends
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"k8s.io/ingress-gce/pkg/annotations"
+	"k8s.io/ingress-gce/pkg/backends/tlsprovider"
+)
+
+type fakeProvider struct {
+	cert *tlsprovider.Cert
+	err  error
+}
+
+func (p *fakeProvider) FetchClientCert(ctx context.Context, sdsCluster, resourceName string) (*tlsprovider.Cert, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	return p.cert, nil
+}
+
+func TestClientTLSCert(t *testing.T) {
+	wantCert := &tlsprovider.Cert{CertPEM: []byte("cert"), KeyPEM: []byte("key")}
+
+	for _, tc := range []struct {
+		desc      string
+		svc       *v1.Service
+		providers tlsprovider.Providers
+		wantCert  *tlsprovider.Cert
+		wantErr   bool
+	}{
+		{
+			desc: "no tls stanza returns nil, nil",
+			svc: &v1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						annotations.BackendConfigKey: `{"default": "config-default"}`,
+					},
+				},
+			},
+			providers: tlsprovider.Providers{},
+		},
+		{
+			desc: "fetches through the registered provider",
+			svc: &v1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						annotations.BackendConfigKey: `{"default": "config-default", "tls": {"clientCertSource": {"sdsCluster": "secretmanager", "resourceName": "projects/p/secrets/s/versions/latest"}}}`,
+					},
+				},
+			},
+			providers: tlsprovider.Providers{"secretmanager": &fakeProvider{cert: wantCert}},
+			wantCert:  wantCert,
+		},
+		{
+			desc: "unregistered sdsCluster errors",
+			svc: &v1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						annotations.BackendConfigKey: `{"default": "config-default", "tls": {"clientCertSource": {"sdsCluster": "secretmanager", "resourceName": "projects/p/secrets/s/versions/latest"}}}`,
+					},
+				},
+			},
+			providers: tlsprovider.Providers{},
+			wantErr:   true,
+		},
+		{
+			desc: "provider fetch error is propagated",
+			svc: &v1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						annotations.BackendConfigKey: `{"default": "config-default", "tls": {"clientCertSource": {"sdsCluster": "secretmanager", "resourceName": "projects/p/secrets/s/versions/latest"}}}`,
+					},
+				},
+			},
+			providers: tlsprovider.Providers{"secretmanager": &fakeProvider{err: fmt.Errorf("boom")}},
+			wantErr:   true,
+		},
+	} {
+		cert, err := clientTLSCert(context.Background(), annotations.FromService(tc.svc), tc.providers)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("%s: clientTLSCert(...) error = %v; wantErr %v", tc.desc, err, tc.wantErr)
+			continue
+		}
+		if tc.wantErr {
+			continue
+		}
+		if cert != tc.wantCert {
+			t.Errorf("%s: clientTLSCert(...) = %v; want %v", tc.desc, cert, tc.wantCert)
+		}
+	}
+}
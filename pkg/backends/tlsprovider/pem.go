@@ -0,0 +1,48 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tlsprovider
+
+import (
+	"encoding/pem"
+	"fmt"
+)
+
+// splitCertAndKey splits concatenated PEM blocks into the certificate
+// chain (all blocks whose Type isn't a private key type) and the private
+// key (all PRIVATE KEY/RSA PRIVATE KEY/EC PRIVATE KEY blocks,
+// concatenated in the order they appear). Blocks are distinguished purely
+// by Type; blank lines between them carry no meaning to pem.Decode.
+func splitCertAndKey(data []byte) (certPEM, keyPEM []byte, err error) {
+	rest := data
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		raw := pem.EncodeToMemory(block)
+		if block.Type == "PRIVATE KEY" || block.Type == "RSA PRIVATE KEY" || block.Type == "EC PRIVATE KEY" {
+			keyPEM = append(keyPEM, raw...)
+		} else {
+			certPEM = append(certPEM, raw...)
+		}
+	}
+	if len(certPEM) == 0 || len(keyPEM) == 0 {
+		return nil, nil, fmt.Errorf("secret does not contain both a certificate and a private key PEM block")
+	}
+	return certPEM, keyPEM, nil
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tlsprovider
+
+import (
+	"context"
+	"fmt"
+)
+
+// xdsProvider fetches client TLS certs over the standard xDS Secret
+// Discovery Service (SDS) protocol, for users whose cert material is
+// managed by a service mesh control plane rather than Secret Manager.
+//
+// This is a stub: it does not speak the SDS gRPC protocol, and its
+// FetchClientCert always returns an error naming the requested cluster
+// and resource. Registering it lets a BackendConfig name an SDS cluster
+// and get that error rather than a "no TLS provider registered" one.
+type xdsProvider struct {
+	sdsCluster string
+}
+
+// NewXDSProvider returns a Provider stub registered under sdsCluster that
+// always reports the SDS protocol as unimplemented.
+func NewXDSProvider(sdsCluster string) Providers {
+	return Providers{
+		sdsCluster: &xdsProvider{sdsCluster: sdsCluster},
+	}
+}
+
+func (p *xdsProvider) FetchClientCert(ctx context.Context, sdsCluster, resourceName string) (*Cert, error) {
+	return nil, fmt.Errorf("xDS/SDS client cert fetching is not yet implemented (cluster %q, resource %q)", sdsCluster, resourceName)
+}
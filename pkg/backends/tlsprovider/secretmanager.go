@@ -0,0 +1,65 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tlsprovider
+
+import (
+	"context"
+	"fmt"
+
+	secretmanager "cloud.google.com/go/secretmanager/apiv1"
+	secretmanagerpb "google.golang.org/genproto/googleapis/cloud/secretmanager/v1"
+)
+
+// secretManagerSDSCluster is the sdsCluster name a BackendConfig's
+// clientCertSource uses to select the Secret Manager provider.
+const secretManagerSDSCluster = "secretmanager"
+
+// secretManagerProvider fetches client TLS certs from Secret Manager,
+// expecting resourceName to be a full Secret Manager version resource name
+// (e.g. "projects/p/secrets/s/versions/latest") whose payload is
+// concatenated PEM blocks: at least one certificate block plus at least
+// one private key block, in any order.
+type secretManagerProvider struct {
+	client *secretmanager.Client
+}
+
+// NewSecretManagerProvider returns a Provider backed by Secret Manager,
+// registered under the "secretmanager" sdsCluster name.
+func NewSecretManagerProvider(client *secretmanager.Client) Providers {
+	return Providers{
+		secretManagerSDSCluster: &secretManagerProvider{client: client},
+	}
+}
+
+func (p *secretManagerProvider) FetchClientCert(ctx context.Context, sdsCluster, resourceName string) (*Cert, error) {
+	if sdsCluster != secretManagerSDSCluster {
+		return nil, fmt.Errorf("secret manager provider does not serve sdsCluster %q", sdsCluster)
+	}
+
+	resp, err := p.client.AccessSecretVersion(ctx, &secretmanagerpb.AccessSecretVersionRequest{
+		Name: resourceName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("accessing secret version %q: %w", resourceName, err)
+	}
+
+	certPEM, keyPEM, err := splitCertAndKey(resp.Payload.Data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing secret version %q: %w", resourceName, err)
+	}
+	return &Cert{CertPEM: certPEM, KeyPEM: keyPEM}, nil
+}
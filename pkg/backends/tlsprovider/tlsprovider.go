@@ -0,0 +1,50 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tlsprovider fetches client TLS certificate material for backend
+// services from sources external to the cluster, as referenced by a
+// Service's BackendConfig "tls" stanza (see
+// annotations.Service.GetBackendTLSSource). This decouples certificate
+// rotation from in-cluster Secret objects.
+package tlsprovider
+
+import "context"
+
+// Cert is a PEM-encoded client certificate and its private key.
+type Cert struct {
+	CertPEM []byte
+	KeyPEM  []byte
+}
+
+// Provider fetches client TLS certificate material referenced by an SDS
+// cluster name and resource name, as named in a BackendConfig's
+// clientCertSource.
+type Provider interface {
+	// FetchClientCert returns the certificate and key named by
+	// resourceName within sdsCluster.
+	FetchClientCert(ctx context.Context, sdsCluster, resourceName string) (*Cert, error)
+}
+
+// Providers is a registry of Provider implementations keyed by the
+// sdsCluster name a BackendConfig's clientCertSource may reference.
+type Providers map[string]Provider
+
+// Get returns the Provider registered for sdsCluster, or false if none is
+// registered.
+func (p Providers) Get(sdsCluster string) (Provider, bool) {
+	provider, ok := p[sdsCluster]
+	return provider, ok
+}
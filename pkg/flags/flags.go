@@ -0,0 +1,40 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package flags defines the global feature flags and configuration used
+// throughout the ingress-gce controllers.
+package flags
+
+// Features contains the flags that gate optional, potentially risky or
+// still-evolving controller behavior.
+type Features struct {
+	// Http2 enables HTTP/2 as a valid application protocol for
+	// Service/Ingress backends.
+	Http2 bool
+	// GRPC enables gRPC and gRPC-with-TLS as valid application protocols
+	// for Service/Ingress backends.
+	GRPC bool
+}
+
+// Flags is the set of global, mutable flags consulted by the controllers.
+// It is intentionally small here; production builds populate it from
+// command-line flags at startup.
+type Flags struct {
+	Features Features
+}
+
+// F is the global flags singleton consulted throughout the codebase.
+var F Flags
@@ -0,0 +1,36 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package healthchecks
+
+import (
+	compute "google.golang.org/api/compute/v1"
+)
+
+// NewGRPCHealthCheck returns a HealthCheck that probes a backend using the
+// gRPC health checking protocol (grpc.health.v1.Health) instead of HTTP.
+// grpcServiceName is passed through to the check as the service name to
+// query; an empty string checks the server's overall status.
+func NewGRPCHealthCheck(name string, grpcServiceName string, port int64) *compute.HealthCheck {
+	return &compute.HealthCheck{
+		Name: name,
+		Type: "GRPC",
+		GrpcHealthCheck: &compute.GRPCHealthCheck{
+			Port:            port,
+			GrpcServiceName: grpcServiceName,
+		},
+	}
+}
@@ -0,0 +1,39 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package healthchecks
+
+import "testing"
+
+func TestNewGRPCHealthCheck(t *testing.T) {
+	hc := NewGRPCHealthCheck("my-hc", "my.Service", 8080)
+
+	if hc.Name != "my-hc" {
+		t.Errorf("hc.Name = %q; want %q", hc.Name, "my-hc")
+	}
+	if hc.Type != "GRPC" {
+		t.Errorf("hc.Type = %q; want %q", hc.Type, "GRPC")
+	}
+	if hc.GrpcHealthCheck == nil {
+		t.Fatal("hc.GrpcHealthCheck = nil; want non-nil")
+	}
+	if hc.GrpcHealthCheck.Port != 8080 {
+		t.Errorf("hc.GrpcHealthCheck.Port = %d; want %d", hc.GrpcHealthCheck.Port, 8080)
+	}
+	if hc.GrpcHealthCheck.GrpcServiceName != "my.Service" {
+		t.Errorf("hc.GrpcHealthCheck.GrpcServiceName = %q; want %q", hc.GrpcHealthCheck.GrpcServiceName, "my.Service")
+	}
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package syncers reconciles the network endpoints of a NEG (Network
+// Endpoint Group) against the Pods backing a Service port.
+package syncers
+
+import (
+	"k8s.io/ingress-gce/pkg/annotations"
+)
+
+// NegName returns the GCE NEG name to use for port, given that port's
+// parsed NegAttributes and the name the controller would otherwise derive
+// on its own. Pinning an explicit name avoids the name (and any load
+// balancing state pointed at it) changing every time the NEG happens to be
+// re-created.
+func NegName(attrs annotations.NegAttributes, defaultName string) string {
+	if attrs.Name != "" {
+		return attrs.Name
+	}
+	return defaultName
+}
+
+// NegEndpointType returns the GCE network endpoint type to use for a NEG
+// built from attrs, defaulting to GCE_VM_IP_PORT.
+func NegEndpointType(attrs annotations.NegAttributes) string {
+	if attrs.EndpointType == "" {
+		return annotations.NegEndpointTypeGCEVMIPPort
+	}
+	return attrs.EndpointType
+}
+
+// IsHybrid returns true if attrs opts this NEG into hybrid mode, where
+// endpoints may live outside of GCE (e.g. on-prem, addressed by private
+// IP rather than a GCE instance).
+func IsHybrid(attrs annotations.NegAttributes) bool {
+	return NegEndpointType(attrs) == annotations.NegEndpointTypeNonGCPPrivateIPPort
+}
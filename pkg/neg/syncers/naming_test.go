@@ -0,0 +1,55 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncers
+
+import (
+	"testing"
+
+	"k8s.io/ingress-gce/pkg/annotations"
+)
+
+func TestNegName(t *testing.T) {
+	for _, tc := range []struct {
+		desc        string
+		attrs       annotations.NegAttributes
+		defaultName string
+		want        string
+	}{
+		{desc: "no pinned name", attrs: annotations.NegAttributes{}, defaultName: "k8s1-foo", want: "k8s1-foo"},
+		{desc: "pinned name", attrs: annotations.NegAttributes{Name: "my-neg"}, defaultName: "k8s1-foo", want: "my-neg"},
+	} {
+		if got := NegName(tc.attrs, tc.defaultName); got != tc.want {
+			t.Errorf("%s: NegName(%+v, %q) = %q; want %q", tc.desc, tc.attrs, tc.defaultName, got, tc.want)
+		}
+	}
+}
+
+func TestIsHybrid(t *testing.T) {
+	for _, tc := range []struct {
+		desc  string
+		attrs annotations.NegAttributes
+		want  bool
+	}{
+		{desc: "default endpointType", attrs: annotations.NegAttributes{}, want: false},
+		{desc: "GCE_VM_IP_PORT", attrs: annotations.NegAttributes{EndpointType: annotations.NegEndpointTypeGCEVMIPPort}, want: false},
+		{desc: "NON_GCP_PRIVATE_IP_PORT", attrs: annotations.NegAttributes{EndpointType: annotations.NegEndpointTypeNonGCPPrivateIPPort}, want: true},
+	} {
+		if got := IsHybrid(tc.attrs); got != tc.want {
+			t.Errorf("%s: IsHybrid(%+v) = %v; want %v", tc.desc, tc.attrs, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package webhook implements the ingress-gce-webhook validating admission
+// webhook, which rejects Services and Ingresses carrying malformed
+// cloud.google.com annotations at apply time rather than letting the
+// controllers silently drop them at reconcile time.
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"k8s.io/ingress-gce/pkg/annotations"
+	"k8s.io/ingress-gce/pkg/annotations/validation"
+)
+
+// ValidateServicePath and ValidateIngressPath are the HTTP paths the
+// webhook serves, matched against the `path` fields of the
+// ValidatingWebhookConfiguration.
+const (
+	ValidateServicePath = "/validate-service"
+	ValidateIngressPath = "/validate-ingress"
+)
+
+// Server serves the validating admission webhook endpoints.
+type Server struct{}
+
+// NewServer returns a Server ready to be registered on an http.ServeMux.
+func NewServer() *Server {
+	return &Server{}
+}
+
+// RegisterHandlers registers the webhook's endpoints on mux.
+func (s *Server) RegisterHandlers(mux *http.ServeMux) {
+	mux.HandleFunc(ValidateServicePath, s.handle(s.validateService))
+	mux.HandleFunc(ValidateIngressPath, s.handle(s.validateIngress))
+}
+
+type validateFunc func(raw []byte) field.ErrorList
+
+func (s *Server) handle(validate validateFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		review, err := readAdmissionReview(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		response := &admissionv1.AdmissionResponse{
+			UID:     review.Request.UID,
+			Allowed: true,
+		}
+		if errs := validate(review.Request.Object.Raw); len(errs) > 0 {
+			response.Allowed = false
+			response.Result = &metav1.Status{
+				Status:  metav1.StatusFailure,
+				Reason:  metav1.StatusReasonInvalid,
+				Message: errs.ToAggregate().Error(),
+			}
+		}
+
+		review.Response = response
+		review.Request = nil
+		writeAdmissionReview(w, review)
+	}
+}
+
+// validateService validates the NEG, app-protocols and backend-config
+// annotations on a raw Service object, returning every error each
+// validator reports (a map-valued annotation can yield one per entry).
+func (s *Server) validateService(raw []byte) field.ErrorList {
+	var svc corev1.Service
+	if err := json.Unmarshal(raw, &svc); err != nil {
+		return field.ErrorList{field.InternalError(field.NewPath(""), err)}
+	}
+
+	var allErrs field.ErrorList
+	if v, ok := svc.Annotations[annotations.NEGAnnotationKey]; ok {
+		allErrs = append(allErrs, validation.ValidateNEGAnnotation(v)...)
+	}
+	if v, ok := svc.Annotations[annotations.ServiceApplicationProtocolKey]; ok {
+		allErrs = append(allErrs, validation.ValidateAppProtocolsAnnotation(validation.AppProtocolsAnnotationPath, v)...)
+	} else if v, ok := svc.Annotations[annotations.GoogleServiceApplicationProtocolKey]; ok {
+		allErrs = append(allErrs, validation.ValidateAppProtocolsAnnotation(validation.GoogleAppProtocolsAnnotationPath, v)...)
+	}
+	if v, ok := svc.Annotations[annotations.BackendConfigKey]; ok {
+		allErrs = append(allErrs, validation.ValidateBackendConfigAnnotation(v)...)
+	}
+	return allErrs
+}
+
+// validateIngress validates the allow-http and pre-shared-cert annotations
+// on a raw Ingress object, returning one error per malformed annotation.
+func (s *Server) validateIngress(raw []byte) field.ErrorList {
+	var ing networkingv1.Ingress
+	if err := json.Unmarshal(raw, &ing); err != nil {
+		return field.ErrorList{field.InternalError(field.NewPath(""), err)}
+	}
+
+	var allErrs field.ErrorList
+	if v, ok := ing.Annotations[annotations.AllowHTTPKey]; ok {
+		allErrs = append(allErrs, validation.ValidateAllowHTTPAnnotation(v)...)
+	}
+	if v, ok := ing.Annotations[annotations.PreSharedCertKey]; ok {
+		allErrs = append(allErrs, validation.ValidatePreSharedCertAnnotation(v)...)
+	}
+	return allErrs
+}
+
+func readAdmissionReview(r *http.Request) (*admissionv1.AdmissionReview, error) {
+	var review admissionv1.AdmissionReview
+	if err := json.NewDecoder(r.Body).Decode(&review); err != nil {
+		return nil, fmt.Errorf("decoding AdmissionReview: %w", err)
+	}
+	if review.Request == nil {
+		return nil, fmt.Errorf("AdmissionReview has no request")
+	}
+	return &review, nil
+}
+
+func writeAdmissionReview(w http.ResponseWriter, review *admissionv1.AdmissionReview) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(review); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
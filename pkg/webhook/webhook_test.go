@@ -0,0 +1,56 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"testing"
+)
+
+func TestValidateService(t *testing.T) {
+	s := NewServer()
+	for _, tc := range []struct {
+		desc    string
+		raw     string
+		wantErr bool
+	}{
+		{
+			desc:    "malformed NEG annotation",
+			raw:     `{"metadata":{"annotations":{"cloud.google.com/neg":"foo"}}}`,
+			wantErr: true,
+		},
+		{
+			desc:    "unknown app protocol",
+			raw:     `{"metadata":{"annotations":{"cloud.google.com/app-protocols":"{\"80\": \"SSH\"}"}}}`,
+			wantErr: true,
+		},
+		{
+			desc:    "backend config key typo",
+			raw:     `{"metadata":{"annotations":{"cloud.google.com/backend-config":"{\"portstypo\":{\"https\": \"config-https\"}}"}}}`,
+			wantErr: true,
+		},
+		{
+			desc:    "no annotations",
+			raw:     `{"metadata":{}}`,
+			wantErr: false,
+		},
+	} {
+		errs := s.validateService([]byte(tc.raw))
+		if (len(errs) > 0) != tc.wantErr {
+			t.Errorf("%s: validateService(...) = %v; wantErr %v", tc.desc, errs, tc.wantErr)
+		}
+	}
+}